@@ -37,8 +37,19 @@ import (
 type Option func(o *option)
 
 type option struct {
-	fileFilter    func(finfo os.FileInfo) bool
-	generatorName string
+	fileFilter     func(finfo os.FileInfo) bool
+	generatorName  string
+	nilSafeGetters bool
+	methodKinds    []MethodKind
+	templateFuncs  template.FuncMap
+	namingStyle    NamingStyle
+	initialisms    map[string]bool
+	syncMode       bool
+	mutexField     string
+	ifaceEnabled   bool
+	ifacePrefix    string
+	ifaceSuffix    string
+	ifaceWriter    func(pkg *ast.Package, kind string) io.Writer
 }
 
 func WithFileFilter(fileFilter func(finfo os.FileInfo) bool) Option {
@@ -53,20 +64,131 @@ func WithGeneratorName(generatorName string) Option {
 	}
 }
 
+// WithNilSafeGetters makes every getter for a pointer, slice, map, or
+// interface field nil-safe by default: the generated method guards against
+// a nil receiver and a nil field, returning the zero value of the
+// underlying type instead of dereferencing or panicking. Individual fields
+// can opt in the same way without this option by adding the "nil-safe"
+// keyword to their getter tag, e.g. `getter:"nil-safe"`.
+func WithNilSafeGetters() Option {
+	return func(o *option) {
+		o.nilSafeGetters = true
+	}
+}
+
+// WithMethodKinds overrides the set of method kinds Run looks for while
+// walking struct fields. It defaults to the kinds registered globally via
+// RegisterMethodKind (which includes the built-in "getter" and "setter"
+// kinds), so this option is only needed when a caller wants to generate a
+// kind that was never registered globally, or wants to scope generation
+// down to a subset of the registry for a single Run.
+func WithMethodKinds(kinds ...MethodKind) Option {
+	return func(o *option) {
+		o.methodKinds = kinds
+	}
+}
+
+// WithTemplateFuncs makes additional template functions available to the
+// file-level wrapper template Run renders around the generated body, on
+// top of the helpers returned by FuncMap (lower, plural, zeroValue, ...).
+// Method kind templates registered via RegisterMethodKind should pull in
+// FuncMap themselves when they need these helpers, since a *template.Template
+// must have its function map attached before it is parsed.
+func WithTemplateFuncs(funcs template.FuncMap) Option {
+	return func(o *option) {
+		o.templateFuncs = funcs
+	}
+}
+
+// WithNamingStyle changes how default method names are derived from a
+// field's name, for every field that doesn't override it with a
+// `style=...` tag option. Defaults to NamingGo.
+func WithNamingStyle(style NamingStyle) Option {
+	return func(o *option) {
+		o.namingStyle = style
+	}
+}
+
+// WithInitialisms adds to (or overrides entries of) the set of
+// initialisms NamingGo capitalizes as a whole, e.g. "id" -> "ID". Pass
+// false for a default entry to stop treating it as an initialism.
+func WithInitialisms(initialisms map[string]bool) Option {
+	return func(o *option) {
+		o.initialisms = initialisms
+	}
+}
+
+// WithSyncMode makes every generated getter and setter guard the field
+// access with the struct's mutex (see WithMutexField), using RLock/RUnlock
+// for getters (Lock/Unlock when the mutex is a plain sync.Mutex) and
+// Lock/Unlock for setters. Individual fields can opt in
+// the same way without this option by adding the "sync" keyword to their
+// getter/setter tag, e.g. `getter:",sync"`. A struct with at least one
+// sync-mode field must declare a sync.Mutex or sync.RWMutex field named
+// after WithMutexField (default "mu"); Run returns an error otherwise.
+func WithSyncMode() Option {
+	return func(o *option) {
+		o.syncMode = true
+	}
+}
+
+// WithMutexField overrides the name of the struct field genaccessor looks
+// for when generating sync-mode accessors (see WithSyncMode). Defaults to
+// "mu".
+func WithMutexField(name string) Option {
+	return func(o *option) {
+		o.mutexField = name
+	}
+}
+
+// WithInterfaceGeneration makes Run also emit, for every struct with at
+// least one generated getter or setter, an interface listing those
+// methods' signatures, named prefix+StructName+suffix (e.g. prefix ""
+// and suffix "Accessor" yields FooAccessor for a struct Foo). By default
+// the interface is written into the same output file as the generated
+// methods; use WithInterfaceWriter to route it elsewhere.
+func WithInterfaceGeneration(prefix, suffix string) Option {
+	return func(o *option) {
+		o.ifaceEnabled = true
+		o.ifacePrefix = prefix
+		o.ifaceSuffix = suffix
+	}
+}
+
+// WithInterfaceWriter routes the interfaces produced by
+// WithInterfaceGeneration to a writer of their own instead of the main
+// output file, so e.g. mocks can live in a different package. newWriter
+// is called once per package, with kind "interface"; Run's own newWriter
+// argument is always used for the generated method implementations (kind
+// "impl" in spirit, though it keeps its original signature for backward
+// compatibility).
+func WithInterfaceWriter(newWriter func(pkg *ast.Package, kind string) io.Writer) Option {
+	return func(o *option) {
+		o.ifaceWriter = newWriter
+	}
+}
+
 func Run(targetDir string, newWriter func(pkg *ast.Package) io.Writer, opts ...Option) error {
 	option := option{
 		generatorName: "go-genaccessor",
+		methodKinds:   MethodKinds(),
+		namingStyle:   NamingGo,
+		mutexField:    "mu",
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
+	initialisms := mergeInitialisms(commonInitialisms, option.initialisms)
+	baseNamer := func(fieldName string) string {
+		return option.namingStyle.rename(fieldName, initialisms)
+	}
 
 	fset := token.NewFileSet()
 	pkgMap, err := parser.ParseDir(
 		fset,
 		filepath.FromSlash(targetDir),
 		option.fileFilter,
-		0,
+		parser.ParseComments,
 	)
 	if err != nil {
 		return err
@@ -75,6 +197,8 @@ func Run(targetDir string, newWriter func(pkg *ast.Package) io.Writer, opts ...O
 	for _, pkg := range pkgMap {
 		body := new(bytes.Buffer)
 		importPackages := make([]*ast.ImportSpec, 0, 10)
+		ifaceStructOrder := make([]string, 0)
+		ifaceMethods := map[string][]string{}
 
 		// sort filelist by name
 		sortedFileNameList := make([]string, 0, len(pkg.Files))
@@ -102,6 +226,7 @@ func Run(targetDir string, newWriter func(pkg *ast.Package) io.Writer, opts ...O
 					if !ok {
 						continue
 					}
+					var optionFields []optionField
 					for _, field := range structType.Fields.List {
 						if field.Tag == nil {
 							continue
@@ -115,15 +240,54 @@ func Run(targetDir string, newWriter func(pkg *ast.Package) io.Writer, opts ...O
 						}
 						fieldTypeText := b.String()
 
-						for _, genMethod := range genMethods {
-							methodNamesText, hasTag := tag.Lookup(genMethod.tagKey)
+						if optTag, hasOpt := tag.Lookup("opt"); hasOpt {
+							withName := optTag
+							if withName == "" {
+								withName = "With" + baseNamer(field.Names[0].Name)
+							}
+							optionFields = append(optionFields, optionField{
+								WithName:  withName,
+								FieldName: field.Names[0].Name,
+								FieldType: fieldTypeText,
+							})
+						}
+
+						for _, methodKind := range option.methodKinds {
+							methodNamesText, hasTag := tag.Lookup(methodKind.TagKey)
 							if !hasTag {
 								continue
 							}
 
-							methodNames := []string{genMethod.defaultMethodName(field.Names[0].Name)}
+							var methodNames []string
+							tagOptions := map[string]string{}
 							if len(methodNamesText) != 0 {
-								methodNames = strings.Split(methodNamesText, ",")
+								methodNames, tagOptions = parseTagParts(strings.Split(methodNamesText, ","))
+							}
+
+							nilSafe := option.nilSafeGetters
+							if methodKind.TagKey == "getter" {
+								methodNames, nilSafe = extractNilSafeKeyword(methodNames, nilSafe)
+							}
+
+							var hasSyncKeyword bool
+							methodNames, hasSyncKeyword = extractKeyword(methodNames, "sync")
+							syncMode := option.syncMode || hasSyncKeyword
+
+							namer := baseNamer
+							if styleKey, ok := tagOptions["style"]; ok {
+								if style, ok := namingStyleByKey(styleKey); ok {
+									namer = func(fieldName string) string {
+										return style.rename(fieldName, initialisms)
+									}
+								}
+							}
+
+							if name, ok := tagOptions["name"]; ok {
+								methodNames = []string{name}
+							}
+
+							if len(methodNames) == 0 {
+								methodNames = []string{methodKind.DefaultName(field.Names[0].Name, namer)}
 							}
 
 							for _, s := range strings.FieldsFunc(fieldTypeText, func(c rune) bool {
@@ -148,54 +312,100 @@ func Run(targetDir string, newWriter func(pkg *ast.Package) io.Writer, opts ...O
 								}
 							}
 
+							tmpl := methodKind.Tmpl
+							param := tmplParam{
+								StructName:  spec.Name.Name,
+								FieldType:   fieldTypeText,
+								FieldName:   field.Names[0].Name,
+								Receiver:    "m",
+								PackageName: pkg.Name,
+								TagOptions:  tagOptions,
+								Field:       field,
+							}
+							nilSafeApplied := false
+							returnTypeText := fieldTypeText
+							if nilSafe && methodKind.TagKey == "getter" {
+								if zero, returnType, deref, ok := zeroValueOf(fset, field.Type); ok {
+									tmpl = nilSafeGetterTmpl
+									param.ZeroValue = zero
+									param.Deref = deref
+									nilSafeApplied = true
+									if deref {
+										returnTypeText = returnType
+										param.FieldType = returnTypeText
+									}
+								}
+							}
+
+							if syncMode && (methodKind.TagKey == "getter" || methodKind.TagKey == "setter") {
+								isRWMutex, hasMutex := mutexFieldKind(structType, option.mutexField)
+								if !hasMutex {
+									return fmt.Errorf("genaccessor: %s.%s requests a sync-mode accessor but struct %s has no %q field of type sync.Mutex/sync.RWMutex; add one or use WithMutexField to point at a different field", spec.Name.Name, field.Names[0].Name, spec.Name.Name, option.mutexField)
+								}
+								param.MutexField = option.mutexField
+								param.MutexReadLock, param.MutexReadUnlock = "Lock", "Unlock"
+								if isRWMutex {
+									param.MutexReadLock, param.MutexReadUnlock = "RLock", "RUnlock"
+								}
+								switch {
+								case methodKind.TagKey == "getter" && nilSafeApplied:
+									tmpl = syncNilSafeGetterTmpl
+								case methodKind.TagKey == "getter":
+									tmpl = syncGetterTmpl
+								default:
+									tmpl = syncSetterTmpl
+								}
+							}
+
 							for _, methodName := range methodNames {
-								if err := genMethod.tmpl.Execute(body, tmplParam{
-									StructName: spec.Name.Name,
-									MethodName: methodName,
-									FieldType:  fieldTypeText,
-									FieldName:  field.Names[0].Name,
-								}); err != nil {
+								if methodName == field.Names[0].Name {
+									return fmt.Errorf("genaccessor: %s.%s's naming style produced the method name %q, identical to the field itself; use a different NamingStyle, an explicit name=... tag option, or WithInitialisms/field renaming to avoid the collision", spec.Name.Name, field.Names[0].Name, methodName)
+								}
+								param.MethodName = methodName
+								if err := tmpl.Execute(body, param); err != nil {
 									panic(err)
 								}
+								if option.ifaceEnabled && (methodKind.TagKey == "getter" || methodKind.TagKey == "setter") {
+									if len(ifaceMethods[spec.Name.Name]) == 0 {
+										ifaceStructOrder = append(ifaceStructOrder, spec.Name.Name)
+									}
+									ifaceMethods[spec.Name.Name] = append(ifaceMethods[spec.Name.Name], interfaceSignature(methodKind.TagKey, methodName, returnTypeText))
+								}
 							}
 						}
 					}
+					if len(optionFields) > 0 && hasOptionsDirective(decl, spec) {
+						if err := optionsTmpl.Execute(body, map[string]interface{}{
+							"StructName": spec.Name.Name,
+							"Fields":     optionFields,
+						}); err != nil {
+							panic(err)
+						}
+					}
 				}
 			}
 		}
-		if body.Len() == 0 {
-			continue
+		ifaceSrc := ""
+		if option.ifaceEnabled && len(ifaceStructOrder) > 0 {
+			ifaceSrc = renderInterfaces(ifaceStructOrder, ifaceMethods, option.ifacePrefix, option.ifaceSuffix)
 		}
-
-		out := new(bytes.Buffer)
-
-		err = template.Must(template.New("out").Parse(`
-			// Code generated by {{ .GeneratorName }}; DO NOT EDIT.
-		
-			package {{ .PackageName }}
-		
-			{{ .ImportPackages }}
-		
-			{{ .Body }}
-		`)).Execute(out, map[string]string{
-			"GeneratorName":  option.generatorName,
-			"PackageName":    pkg.Name,
-			"ImportPackages": fmtImports(importPackages, fset),
-			"Body":           body.String(),
-		})
-		if err != nil {
-			return err
+		implBody := body.String()
+		if ifaceSrc != "" && option.ifaceWriter == nil {
+			implBody += ifaceSrc
 		}
 
-		str, err := format.Source(out.Bytes())
-		if err != nil {
-			return err
+		funcs := mergeFuncMaps(FuncMap(), option.templateFuncs)
+
+		if ifaceSrc != "" && option.ifaceWriter != nil {
+			if err := writeGeneratedFile(option.ifaceWriter(pkg, "interface"), option.generatorName, pkg.Name, "", ifaceSrc, funcs); err != nil {
+				return err
+			}
 		}
-		writer := newWriter(pkg)
-		if closer, ok := writer.(io.Closer); ok {
-			defer closer.Close()
+
+		if implBody == "" {
+			continue
 		}
-		if _, err := writer.Write(str); err != nil {
+		if err := writeGeneratedFile(newWriter(pkg), option.generatorName, pkg.Name, fmtImports(importPackages, fset), implBody, funcs); err != nil {
 			return err
 		}
 	}
@@ -203,41 +413,396 @@ func Run(targetDir string, newWriter func(pkg *ast.Package) io.Writer, opts ...O
 	return nil
 }
 
+// tmplParam is the data made available to a MethodKind's template while
+// rendering one generated method for one struct field.
 type tmplParam struct {
 	StructName string
 	MethodName string
 	FieldType  string
 	FieldName  string
+	ZeroValue  string
+	Deref      bool
+
+	// Receiver is the resolved receiver name used in the generated method
+	// signature. It is "m" for every built-in kind today, but is exposed so
+	// custom templates registered via RegisterMethodKind don't have to
+	// hard-code it.
+	Receiver string
+	// PackageName is the name of the package the field's struct lives in.
+	PackageName string
+	// TagOptions holds the key=value entries parsed out of the tag body,
+	// e.g. `getter:"name=FooID,style=pascal"` yields
+	// {"name": "FooID", "style": "pascal"}.
+	TagOptions map[string]string
+	// Field is the raw AST node for the struct field being processed, for
+	// templates that need to inspect it directly (doc comments, further
+	// tags, etc).
+	Field *ast.Field
+	// MutexField is the name of the struct field holding the sync.Mutex
+	// or sync.RWMutex used to guard a sync-mode accessor (see
+	// WithSyncMode).
+	MutexField string
+	// MutexReadLock and MutexReadUnlock are the methods a sync-mode getter
+	// calls to take and release its read-side lock: "RLock"/"RUnlock" for
+	// a sync.RWMutex, or "Lock"/"Unlock" for a plain sync.Mutex, which has
+	// no reader lock.
+	MutexReadLock   string
+	MutexReadUnlock string
+}
+
+// MethodKind describes one kind of accessor method genaccessor knows how
+// to generate: the tag key that opts a field in (e.g. "getter"), the
+// template that renders the method body, and how to derive a default
+// method name from the field name when the tag carries no explicit name.
+type MethodKind struct {
+	Name   string
+	TagKey string
+	Tmpl   *template.Template
+	// DefaultName derives a method name from a field name when its tag
+	// carries no explicit name, using namer (built from the naming style
+	// in effect - see WithNamingStyle) to do any case conversion.
+	DefaultName func(fieldName string, namer func(string) string) string
 }
 
-var genMethods = []struct {
-	tagKey            string
-	tmpl              *template.Template
-	defaultMethodName func(filedName string) string
-}{
-	{
-		tagKey: "getter",
-		tmpl: template.Must(template.New("getter").Parse(`
+var methodKindRegistry []MethodKind
+
+// RegisterMethodKind adds a method kind to the global registry used by
+// Run whenever the caller does not pass WithMethodKinds explicitly. It
+// lets callers add support for their own tag keys - e.g. `with:"..."` for
+// functional options or `mutator:"..."` for validated setters - without
+// forking this package. tmpl is executed once per generated method with a
+// tmplParam as its data.
+func RegisterMethodKind(name, tagKey string, tmpl *template.Template, defaultName func(fieldName string, namer func(string) string) string) {
+	methodKindRegistry = append(methodKindRegistry, MethodKind{
+		Name:        name,
+		TagKey:      tagKey,
+		Tmpl:        tmpl,
+		DefaultName: defaultName,
+	})
+}
+
+// MethodKinds returns a copy of the globally registered method kinds, in
+// registration order.
+func MethodKinds() []MethodKind {
+	kinds := make([]MethodKind, len(methodKindRegistry))
+	copy(kinds, methodKindRegistry)
+	return kinds
+}
+
+func init() {
+	RegisterMethodKind("getter", "getter", template.Must(template.New("getter").Parse(`
 func (m {{ .StructName }}) {{ .MethodName }}() {{ .FieldType }} {
 				return m.{{ .FieldName }}
 			}
-		`)),
-		defaultMethodName: toUpperCamel,
-	},
-	{
-		tagKey: "setter",
-		tmpl: template.Must(template.New("getter").Parse(`
+		`)), func(fieldName string, namer func(string) string) string {
+		return namer(fieldName)
+	})
+
+	RegisterMethodKind("setter", "setter", template.Must(template.New("setter").Parse(`
+func (m *{{ .StructName }}) {{ .MethodName }}(s {{ .FieldType }}) {
+				m.{{ .FieldName }} = s
+			}
+		`)), func(fieldName string, namer func(string) string) string {
+		return "Set" + namer(fieldName)
+	})
+
+	RegisterMethodKind("chain", "chain", template.Must(template.New("chain").Parse(`
+func (m *{{ .StructName }}) {{ .MethodName }}(v {{ .FieldType }}) *{{ .StructName }} {
+				m.{{ .FieldName }} = v
+				return m
+			}
+		`)), func(fieldName string, namer func(string) string) string {
+		return "With" + namer(fieldName)
+	})
+}
+
+var nilSafeGetterTmpl = template.Must(template.New("nil-safe-getter").Parse(`
+func (m *{{ .StructName }}) {{ .MethodName }}() {{ .FieldType }} {
+				if m == nil || m.{{ .FieldName }} == nil {
+					return {{ .ZeroValue }}
+				}
+				return {{ if .Deref }}*{{ end }}m.{{ .FieldName }}
+			}
+		`))
+
+var syncGetterTmpl = template.Must(template.New("sync-getter").Parse(`
+func (m *{{ .StructName }}) {{ .MethodName }}() {{ .FieldType }} {
+				m.{{ .MutexField }}.{{ .MutexReadLock }}()
+				defer m.{{ .MutexField }}.{{ .MutexReadUnlock }}()
+				return m.{{ .FieldName }}
+			}
+		`))
+
+var syncNilSafeGetterTmpl = template.Must(template.New("sync-nil-safe-getter").Parse(`
+func (m *{{ .StructName }}) {{ .MethodName }}() {{ .FieldType }} {
+				if m == nil {
+					return {{ .ZeroValue }}
+				}
+				m.{{ .MutexField }}.{{ .MutexReadLock }}()
+				defer m.{{ .MutexField }}.{{ .MutexReadUnlock }}()
+				if m.{{ .FieldName }} == nil {
+					return {{ .ZeroValue }}
+				}
+				return {{ if .Deref }}*{{ end }}m.{{ .FieldName }}
+			}
+		`))
+
+var syncSetterTmpl = template.Must(template.New("sync-setter").Parse(`
 func (m *{{ .StructName }}) {{ .MethodName }}(s {{ .FieldType }}) {
+				m.{{ .MutexField }}.Lock()
+				defer m.{{ .MutexField }}.Unlock()
 				m.{{ .FieldName }} = s
 			}
-		`)),
-		defaultMethodName: func(fieldName string) string {
-			return "Set" + toUpperCamel(fieldName)
-		},
-	},
+		`))
+
+// optionField describes one field eligible for functional-option
+// construction, tagged with `opt:"..."` on a struct carrying the
+// //genaccessor:options directive comment.
+type optionField struct {
+	WithName  string
+	FieldName string
+	FieldType string
+}
+
+var optionsTmpl = template.Must(template.New("options").Parse(`
+type {{ .StructName }}Option func(*{{ .StructName }})
+
+func New{{ .StructName }}(opts ...{{ .StructName }}Option) *{{ .StructName }} {
+	m := &{{ .StructName }}{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+{{ range .Fields }}
+func {{ .WithName }}(v {{ .FieldType }}) {{ $.StructName }}Option {
+	return func(m *{{ $.StructName }}) {
+		m.{{ .FieldName }} = v
+	}
+}
+{{ end }}`))
+
+// hasOptionsDirective reports whether decl or spec carries a
+// //genaccessor:options comment, the struct-level directive that opts a
+// type into functional-option construction (see optionField).
+func hasOptionsDirective(decl *ast.GenDecl, spec *ast.TypeSpec) bool {
+	for _, doc := range []*ast.CommentGroup{decl.Doc, spec.Doc} {
+		if doc == nil {
+			continue
+		}
+		for _, c := range doc.List {
+			if strings.Contains(c.Text, "genaccessor:options") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mutexFieldKind reports whether structType declares a field named name
+// whose type is sync.Mutex or sync.RWMutex (or a pointer to either), which
+// Run requires when generating a sync-mode accessor, and whether that
+// field is specifically a sync.RWMutex - a plain sync.Mutex has no
+// RLock/RUnlock, so sync-mode getters must fall back to its Lock/Unlock.
+func mutexFieldKind(structType *ast.StructType, name string) (isRWMutex, found bool) {
+	for _, field := range structType.Fields.List {
+		for _, fieldName := range field.Names {
+			if fieldName.Name != name {
+				continue
+			}
+			switch mutexTypeName(field.Type) {
+			case "RWMutex":
+				return true, true
+			case "Mutex":
+				return false, true
+			}
+		}
+	}
+	return false, false
+}
+
+// mutexTypeName returns "Mutex" or "RWMutex" if expr denotes sync.Mutex or
+// sync.RWMutex (or a pointer to either), and "" for any other type.
+func mutexTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok || pkgIdent.Name != "sync" {
+		return ""
+	}
+	switch sel.Sel.Name {
+	case "Mutex", "RWMutex":
+		return sel.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// extractKeyword looks for a bare keyword entry in aliases (the
+// comma-separated, non key=value contents of a tag) and strips it out,
+// returning the remaining method name aliases and whether the keyword was
+// present. Empty aliases (e.g. the leading blank left by a tag such as
+// `getter:",sync"`, which has no explicit name) are dropped too, so a bare
+// keyword with no alias still falls back to the default method name.
+func extractKeyword(aliases []string, keyword string) ([]string, bool) {
+	found := false
+	remaining := aliases[:0:0]
+	for _, alias := range aliases {
+		if alias == keyword {
+			found = true
+			continue
+		}
+		if alias == "" {
+			continue
+		}
+		remaining = append(remaining, alias)
+	}
+	return remaining, found
+}
+
+// extractNilSafeKeyword looks for a "nil-safe" entry in methodNames (the
+// comma-separated contents of a getter tag) and strips it out, returning
+// the remaining method name aliases and whether nil-safe generation was
+// requested, either explicitly via the keyword or via defaultNilSafe.
+func extractNilSafeKeyword(methodNames []string, defaultNilSafe bool) ([]string, bool) {
+	names, found := extractKeyword(methodNames, "nil-safe")
+	return names, defaultNilSafe || found
+}
+
+// parseTagParts splits the comma-separated contents of a tag value into
+// plain aliases (e.g. "nil-safe", or a bare method name) and key=value
+// options (e.g. "style=pascal"), so templates can be driven by either.
+func parseTagParts(parts []string) (aliases []string, options map[string]string) {
+	options = map[string]string{}
+	for _, part := range parts {
+		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+			options[kv[0]] = kv[1]
+			continue
+		}
+		aliases = append(aliases, part)
+	}
+	return aliases, options
+}
+
+// zeroValueOf classifies expr (a field's AST type) and, if it is a
+// pointer, slice, map, or interface type, returns the Go literal for its
+// zero value, the return type the nil-safe getter must declare (the
+// pointed-to type when deref is true, since the generated body returns
+// *m.field rather than m.field; "" otherwise, as the getter then keeps
+// the field's own type), and whether
+// the field itself needs to be dereferenced to obtain the accessor's
+// return value. ok is false for any other kind, meaning nil-safe
+// generation does not apply; in particular a fixed-size array (`[N]T`, as
+// opposed to a slice `[]T`) is not nilable and is reported as ok == false.
+func zeroValueOf(fset *token.FileSet, expr ast.Expr) (zero, returnType string, deref, ok bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		b := new(bytes.Buffer)
+		if err := printer.Fprint(b, fset, t.X); err != nil {
+			panic(err)
+		}
+		return zeroValueForType(fset, t.X), b.String(), true, true
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", "", false, false
+		}
+		return "nil", "", false, true
+	case *ast.MapType, *ast.InterfaceType:
+		return "nil", "", false, true
+	default:
+		return "", "", false, false
+	}
+}
+
+// zeroValueForType returns the zero-value literal for the pointed-to type
+// of a pointer field, e.g. `""` for *string, `0` for *int, and `T{}` for
+// a pointer to a named or imported struct type.
+func zeroValueForType(fset *token.FileSet, expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		switch ident.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"byte", "rune",
+			"float32", "float64",
+			"complex64", "complex128":
+			return "0"
+		}
+	}
+
+	b := new(bytes.Buffer)
+	if err := printer.Fprint(b, fset, expr); err != nil {
+		panic(err)
+	}
+	return b.String() + "{}"
+}
+
+// NamingStyle controls how a default method name is derived from a
+// struct field's name when its tag carries no explicit method name.
+type NamingStyle struct {
+	name   string
+	rename func(fieldName string, initialisms map[string]bool) string
+}
+
+var (
+	// NamingGo is the default style: PascalCase with common initialisms
+	// (see WithInitialisms) capitalized as a whole, e.g. "id" -> "ID".
+	NamingGo = NamingStyle{name: "go", rename: toUpperCamelWithInitialisms}
+	// NamingPascal is plain PascalCase, ignoring initialisms.
+	NamingPascal = NamingStyle{name: "pascal", rename: func(s string, _ map[string]bool) string { return toPascalCase(s) }}
+	// NamingSnake is snake_case.
+	NamingSnake = NamingStyle{name: "snake", rename: func(s string, _ map[string]bool) string { return toSnakeCase(s) }}
+	// NamingCamelLower is lowerCamelCase.
+	NamingCamelLower = NamingStyle{name: "camelLower", rename: func(s string, _ map[string]bool) string { return toLowerCamel(s) }}
+)
+
+// NamingCustom wraps an arbitrary field-name-to-method-name function as a
+// NamingStyle, for naming conventions none of the built-in styles cover.
+func NamingCustom(fn func(fieldName string) string) NamingStyle {
+	return NamingStyle{name: "custom", rename: func(s string, _ map[string]bool) string { return fn(s) }}
+}
+
+func namingStyleByKey(key string) (NamingStyle, bool) {
+	switch key {
+	case "go":
+		return NamingGo, true
+	case "pascal":
+		return NamingPascal, true
+	case "snake":
+		return NamingSnake, true
+	case "camelLower":
+		return NamingCamelLower, true
+	default:
+		return NamingStyle{}, false
+	}
+}
+
+// mergeInitialisms returns a new map containing every entry of base,
+// overridden by any entry present in extra.
+func mergeInitialisms(base, extra map[string]bool) map[string]bool {
+	merged := make(map[string]bool, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
 }
 
 func toUpperCamel(s string) string {
+	return toUpperCamelWithInitialisms(s, commonInitialisms)
+}
+
+func toUpperCamelWithInitialisms(s string, initialisms map[string]bool) string {
 	if s == "" {
 		return s
 	}
@@ -247,12 +812,59 @@ func toUpperCamel(s string) string {
 	if firstNotLowerIndex == -1 {
 		firstNotLowerIndex = len(s)
 	}
-	if commonInitialisms[s[:firstNotLowerIndex]] {
+	if initialisms[s[:firstNotLowerIndex]] {
 		return strings.ToUpper(s[:firstNotLowerIndex]) + s[firstNotLowerIndex:]
 	}
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
+// splitWords splits a camelCase or PascalCase identifier into its
+// constituent words, e.g. "fooBarID" -> ["foo", "Bar", "ID"].
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	var current []rune
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+func toPascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+	return b.String()
+}
+
+func toLowerCamel(s string) string {
+	pascal := toPascalCase(s)
+	if pascal == "" {
+		return pascal
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+func toSnakeCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
 // from https://github.com/golang/lint
 var commonInitialisms = map[string]bool{
 	"acl":   true,
@@ -295,6 +907,65 @@ var commonInitialisms = map[string]bool{
 	"xss":   true,
 }
 
+// writeGeneratedFile wraps bodyText in the standard generated-file header
+// (generator notice, package clause, imports) and writes the gofmt'ed
+// result to w.
+func writeGeneratedFile(w io.Writer, generatorName, packageName, importsText, bodyText string, funcs template.FuncMap) error {
+	out := new(bytes.Buffer)
+	outTmpl := template.Must(template.New("out").Funcs(funcs).Parse(`
+		// Code generated by {{ .GeneratorName }}; DO NOT EDIT.
+
+		package {{ .PackageName }}
+
+		{{ .ImportPackages }}
+
+		{{ .Body }}
+	`))
+	if err := outTmpl.Execute(out, map[string]string{
+		"GeneratorName":  generatorName,
+		"PackageName":    packageName,
+		"ImportPackages": importsText,
+		"Body":           bodyText,
+	}); err != nil {
+		return err
+	}
+
+	str, err := format.Source(out.Bytes())
+	if err != nil {
+		return err
+	}
+	if closer, ok := w.(io.Closer); ok {
+		defer closer.Close()
+	}
+	_, err = w.Write(str)
+	return err
+}
+
+// interfaceSignature renders the interface method signature generated
+// for a getter or setter, e.g. ("getter", "Bar", "string") -> "Bar() string"
+// and ("setter", "SetBar", "string") -> "SetBar(string)".
+func interfaceSignature(tagKey, methodName, fieldType string) string {
+	if tagKey == "setter" {
+		return fmt.Sprintf("%s(%s)", methodName, fieldType)
+	}
+	return fmt.Sprintf("%s() %s", methodName, fieldType)
+}
+
+// renderInterfaces renders one interface declaration per struct name in
+// order, each listing that struct's generated getter/setter signatures in
+// the order they were generated.
+func renderInterfaces(order []string, methods map[string][]string, prefix, suffix string) string {
+	b := new(strings.Builder)
+	for _, structName := range order {
+		fmt.Fprintf(b, "\ntype %s%s%s interface {\n", prefix, structName, suffix)
+		for _, sig := range methods[structName] {
+			fmt.Fprintf(b, "\t%s\n", sig)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
 func fmtImports(pkgs []*ast.ImportSpec, fset *token.FileSet) string {
 	if len(pkgs) == 0 {
 		return ""
@@ -338,3 +1009,88 @@ func fmtImports(pkgs []*ast.ImportSpec, fset *token.FileSet) string {
 		b.String(),
 	)
 }
+
+// FuncMap returns the template helper functions genaccessor makes
+// available to templates it builds internally, and that custom templates
+// registered via RegisterMethodKind can pull in themselves, e.g.
+//
+//	tmpl := template.Must(template.New("with").Funcs(genaccessor.FuncMap()).Parse(`...`))
+//
+// It currently provides "lower" (strings.ToLower), "plural" (a naive
+// English pluralizer), "upperCamel" (this package's Go-style camel-casing,
+// including common initialisms), and "zeroValue" (the zero-value literal
+// for a field type rendered as source text, e.g. "*string" -> `""`).
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"lower":      strings.ToLower,
+		"plural":     pluralize,
+		"upperCamel": toUpperCamel,
+		"zeroValue":  zeroValueFromTypeText,
+	}
+}
+
+// mergeFuncMaps returns a new FuncMap containing every entry of base,
+// overridden by any entry present in extra.
+func mergeFuncMaps(base, extra template.FuncMap) template.FuncMap {
+	merged := make(template.FuncMap, len(base)+len(extra))
+	for name, fn := range base {
+		merged[name] = fn
+	}
+	for name, fn := range extra {
+		merged[name] = fn
+	}
+	return merged
+}
+
+// pluralize is a deliberately naive English pluralizer, good enough for
+// template helpers that just need "items" from "item".
+func pluralize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"):
+		return s + "es"
+	default:
+		return s + "s"
+	}
+}
+
+// zeroValueFromTypeText returns the zero-value literal for a field type
+// rendered as Go source text, e.g. "*string" -> `""`, "[]int" -> "nil",
+// "bool" -> "false", "int" -> "0", and anything else -> "TypeName{}".
+func zeroValueFromTypeText(fieldType string) string {
+	switch {
+	case strings.HasPrefix(fieldType, "*"):
+		base := strings.TrimPrefix(fieldType, "*")
+		switch base {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"byte", "rune",
+			"float32", "float64",
+			"complex64", "complex128":
+			return "0"
+		default:
+			return base + "{}"
+		}
+	case strings.HasPrefix(fieldType, "[]"), strings.HasPrefix(fieldType, "map["), fieldType == "interface{}", fieldType == "any":
+		return "nil"
+	case fieldType == "string":
+		return `""`
+	case fieldType == "bool":
+		return "false"
+	default:
+		switch fieldType {
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"byte", "rune",
+			"float32", "float64",
+			"complex64", "complex128":
+			return "0"
+		}
+		return fieldType + "{}"
+	}
+}